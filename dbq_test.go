@@ -6,6 +6,7 @@ import (
 	"context"
 	"database/sql/driver"
 	"fmt"
+	"golang.org/x/xerrors"
 	"testing"
 	"time"
 
@@ -455,3 +456,116 @@ func TestSingleQueryRawResult(t *testing.T) {
 		t.Errorf("wrong val: expected: %T %v actual: %T %v", expected, expected, actual, actual)
 	}
 }
+
+func TestKind(t *testing.T) {
+
+	tests := []struct {
+		query    string
+		expected StatementKind
+	}{
+		{"SELECT * FROM store", KindSelect},
+		{"  select * from store", KindSelect},
+		{"/* hint */ SELECT * FROM store", KindSelect},
+		{"-- comment\nINSERT INTO store VALUES (1)", KindInsert},
+		{"# comment\nUPDATE store SET product = 'x'", KindUpdate},
+		{"(SELECT id FROM a) UNION (SELECT id FROM b)", KindSelect},
+		{"EXPLAIN DELETE FROM store", KindExplain},
+		{"REPLACE INTO store VALUES (1)", KindReplace},
+		{"WITH old AS (SELECT id FROM store WHERE product = 'DELETE') DELETE FROM store WHERE id IN (SELECT id FROM old)", KindDelete},
+		{"WITH recent AS (SELECT * FROM store) SELECT * FROM recent", KindSelect},
+		{"CREATE TABLE store (id INT)", KindOther},
+		{"/* unterminated", KindUnknown},
+		{"", KindUnknown},
+	}
+
+	for _, tc := range tests {
+		if actual := Kind(tc.query); actual != tc.expected {
+			t.Errorf("wrong kind for %q: expected: %v actual: %v", tc.query, tc.expected, actual)
+		}
+	}
+
+	// # is an operator (not a comment) for PostgreSQL
+	if actual := Kind("SELECT 1 # 2 AS x; DELETE FROM store", PostgreSQL); actual != KindSelect {
+		t.Errorf("wrong kind for PostgreSQL: expected: %v actual: %v", KindSelect, actual)
+	}
+	if actual := Kind("# comment\nUPDATE store SET product = 'x'", PostgreSQL); actual != KindOther {
+		t.Errorf("wrong kind for PostgreSQL: expected: %v actual: %v", KindOther, actual)
+	}
+}
+
+func TestCommentedExec(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM store").
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctx := context.Background()
+
+	// Leading comment must not cause E to misroute the statement
+	_ = MustE(ctx, db, "/* purge */ DELETE FROM store WHERE id = ?", nil, int64(1))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO store").
+		WithArgs("apple").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+	ctx := context.Background()
+
+	// Query always returns rows, even for exec statements
+	res := MustQuery(ctx, db, "INSERT INTO store (product) VALUES (?) RETURNING id", &Options{ConcreteStruct: store{}, SingleResult: true}, []string{"apple"})
+
+	if actual := res.(*store); actual.ID != 7 {
+		t.Errorf("wrong result: %+v", actual)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWrongStatementKind(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	_, err = Exec(ctx, db, "SELECT * FROM store", nil)
+	if !xerrors.Is(err, ErrWrongStatementKind) {
+		t.Errorf("wrong error: %v", err)
+	}
+
+	// E panics with the same error
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	func() {
+		defer func() {
+			r := recover()
+			if err, ok := r.(error); !ok || !xerrors.Is(err, ErrWrongStatementKind) {
+				t.Errorf("wrong panic: %v", r)
+			}
+		}()
+		E(ctx, db, "SELECT * FROM store", nil)
+	}()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
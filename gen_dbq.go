@@ -8,13 +8,13 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/xerrors"
 	"reflect"
 	"runtime"
 	"strconv"
-	"strings"
 	"time"
 
 	"cloud.google.com/go/civil"
@@ -102,8 +102,14 @@ type Options struct {
 	// RawResults can be set to true for results to be returned unprocessed ([]byte).
 	// This option does nothing if ConcreteStruct is provided.
 	RawResults bool
+
+	// DBType is used to determine the type of statement (see Kind). The default is MySQL.
+	DBType Database
 }
 
+// ErrWrongStatementKind is returned by Exec when the statement is not an INSERT, UPDATE, DELETE or REPLACE statement.
+var ErrWrongStatementKind = errors.New("wrong statement kind")
+
 // MustE is a wrapper around the E function. It will panic upon encountering an error.
 // This can erradicate boiler-plate error handing code.
 func MustE(ctx context.Context, db ExecContexter, query string, options *Options, args ...interface{}) sql.Result {
@@ -116,6 +122,9 @@ func MustE(ctx context.Context, db ExecContexter, query string, options *Options
 
 // E is a wrapper around the Q function. It is used for "Exec" queries such as insert, update and delete.
 // It also returns a sql.Result interface instead of an empty interface.
+//
+// If the statement returns rows, E panics with an error that wraps ErrWrongStatementKind.
+// Use Exec to receive the error instead.
 func E(ctx context.Context, db ExecContexter, query string, options *Options, args ...interface{}) (sql.Result, error) {
 
 	res, err := Q(ctx, db, query, options, args...)
@@ -123,11 +132,41 @@ func E(ctx context.Context, db ExecContexter, query string, options *Options, ar
 		return nil, err
 	}
 
-	return res.(sql.Result), nil
+	result, ok := res.(sql.Result)
+	if !ok {
+		panic(xerrors.Errorf("%v statement: %w", Kind(query, dbType(options)), ErrWrongStatementKind))
+	}
+	return result, nil
+}
+
+// MustExec is a wrapper around the Exec function. It will panic upon encountering an error.
+// This can erradicate boiler-plate error handing code.
+func MustExec(ctx context.Context, db ExecContexter, query string, options *Options, args ...interface{}) sql.Result {
+	rTHLSe, llPWNy := Exec(ctx, db, query, options, args...)
+	if llPWNy != nil {
+		panic(llPWNy)
+	}
+	return rTHLSe
+}
+
+// Exec is used for "Exec" queries such as insert, update and delete. The type of statement is determined by Kind.
+// If it is not an INSERT, UPDATE, DELETE or REPLACE statement, an error that wraps ErrWrongStatementKind
+// is returned without executing it.
+//
+// args is a list of values to replace the placeholders in the query. A single slice
+// (of any slice type) can be provided for the first arg. If so, the values will automatically be flattened to a list
+// of interface{}.
+func Exec(ctx context.Context, db ExecContexter, query string, options *Options, args ...interface{}) (sql.Result, error) {
+	if kind := Kind(query, dbType(options)); !kind.IsExec() {
+		return nil, xerrors.Errorf("%v statement: %w", kind, ErrWrongStatementKind)
+	}
+	return db.ExecContext(ctx, query, flattenArgs(args)...)
 }
 
 // MustQ is a wrapper around the Q function. It will panic upon encountering an error.
 // This can erradicate boiler-plate error handing code.
+//
+// Deprecated: Use MustQuery or MustE instead.
 func MustQ(ctx context.Context, db interface{}, query string, options *Options, args ...interface{}) interface{} {
 	TMtTCo, aNatyy := Q(ctx, db, query, options, args...)
 	if aNatyy != nil {
@@ -137,30 +176,51 @@ func MustQ(ctx context.Context, db interface{}, query string, options *Options,
 }
 
 // Q is a convenience function that is used for inserting, updating, deleting, and querying a SQL database.
-// For inserts, updates, and deletes, a sql.Result is returned.
-// For queries, a []map[string]interface{} is ordinarily returned. Each result (an item in the slice) contains
-// a map where the keys are the columns, and the values are the data for the column.
+// For inserts, updates, deletes and replaces, a sql.Result is returned. The type of statement is determined by Kind,
+// which ignores leading comments and parentheses.
+// For queries, the results are returned as described by Query.
+//
+// Deprecated: The type of the returned value depends on the statement, which must be type asserted accordingly.
+// Use Query for statements that return rows and E for all other statements.
+func Q(ctx context.Context, db interface{}, query string, options *Options, args ...interface{}) (interface{}, error) {
+	if Kind(query, dbType(options)).IsExec() {
+		return db.(ExecContexter).ExecContext(ctx, query, flattenArgs(args)...)
+	}
+	return Query(ctx, db, query, options, args...)
+}
+
+// MustQuery is a wrapper around the Query function. It will panic upon encountering an error.
+// This can erradicate boiler-plate error handing code.
+func MustQuery(ctx context.Context, db interface{}, query string, options *Options, args ...interface{}) interface{} {
+	XZrLbP, DbRqKN := Query(ctx, db, query, options, args...)
+	if DbRqKN != nil {
+		panic(DbRqKN)
+	}
+	return XZrLbP
+}
+
+// Query is used for querying a SQL database. A []map[string]interface{} is ordinarily returned. Each result
+// (an item in the slice) contains a map where the keys are the columns, and the values are the data for the column.
 // When a ConcreteStruct is provided via the Options, the mapstructure package is used to automatically
 // return []structs instead. args is a list of values to replace the placeholders in the query. A single slice
 // (of any slice type) can be provided for the first arg. If so, the values will automatically be flattened to a list
 // of interface{}.
 //
+// Unlike Q, the statement is always executed as a query. This allows statements such as INSERT ... RETURNING
+// to return rows.
+//
 // NOTE: sql.ErrNoRows is never returned as an error: usually, a slice is returned, unless the
 // behavior is modified by the SingleResult Option.
-func Q(ctx context.Context, db interface{}, query string, options *Options, args ...interface{}) (out interface{}, rErr error) {
-
-	var (
-		o        Options
-		wasQuery bool
-	)
+func Query(ctx context.Context, db interface{}, query string, options *Options, args ...interface{}) (out interface{}, rErr error) {
 
+	var o Options
 	if options != nil {
 		o = *options
 	}
 
 	defer func() {
 		if rErr == nil {
-			if wasQuery && o.SingleResult {
+			if o.SingleResult {
 				rows := reflect.ValueOf(out)
 				if rows.Len() == 0 {
 					out = nil
@@ -172,42 +232,7 @@ func Q(ctx context.Context, db interface{}, query string, options *Options, args
 		}
 	}()
 
-	query = strings.TrimSpace(query)
-	if strings.HasPrefix(query, "(") && strings.HasSuffix(query, ")") {
-		query = strings.TrimPrefix(query, "(")
-		query = strings.TrimSuffix(query, ")")
-	}
-	queryType := query[0:6]
-
-	foundSliceArg := false
-	for _, v := range args {
-		if arg := reflect.ValueOf(v); arg.Kind() == reflect.Slice {
-			foundSliceArg = true
-			break
-		}
-	}
-
-	if foundSliceArg {
-		newArgs := []interface{}{}
-		for _, v := range args {
-			if arg := reflect.ValueOf(v); arg.Kind() == reflect.Slice {
-				newArgs = append(newArgs, sliceConv(arg)...)
-			} else {
-				newArgs = append(newArgs, v)
-			}
-		}
-		args = newArgs
-	}
-
-	if queryType == "INSERT" || queryType == "insert" {
-		return db.(ExecContexter).ExecContext(ctx, query, args...)
-	} else if queryType == "UPDATE" || queryType == "update" {
-		return db.(ExecContexter).ExecContext(ctx, query, args...)
-	} else if queryType == "DELETE" || queryType == "delete" {
-		return db.(ExecContexter).ExecContext(ctx, query, args...)
-	}
-
-	wasQuery = true
+	args = flattenArgs(args)
 
 	var (
 		outStruct = []interface{}{}
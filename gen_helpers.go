@@ -98,6 +98,40 @@ func sliceConv(arg reflect.Value) []interface{} {
 	return out
 }
 
+// dbType returns the DBType option.
+func dbType(options *Options) Database {
+	if options == nil {
+		return MySQL
+	}
+	return options.DBType
+}
+
+// flattenArgs flattens the args that are slices to a list of interface{}.
+func flattenArgs(args []interface{}) []interface{} {
+
+	foundSliceArg := false
+	for _, v := range args {
+		if arg := reflect.ValueOf(v); arg.Kind() == reflect.Slice {
+			foundSliceArg = true
+			break
+		}
+	}
+
+	if !foundSliceArg {
+		return args
+	}
+
+	newArgs := []interface{}{}
+	for _, v := range args {
+		if arg := reflect.ValueOf(v); arg.Kind() == reflect.Slice {
+			newArgs = append(newArgs, sliceConv(arg)...)
+		} else {
+			newArgs = append(newArgs, v)
+		}
+	}
+	return newArgs
+}
+
 // StdTimeConversionConfig provides a standard configuration for unmarshaling to
 // time-related fields in a struct. It properly converts timestamps and datetime columns into
 // time.Time objects. It assumes a MySQL database as default.
@@ -0,0 +1,164 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dbqhttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rocketlaunchr/dbq/v2"
+)
+
+func TestHandler(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	var failures []string
+	h := NewHandler(db, HandlerOptions{
+		Timeout: time.Second,
+		OnError: func(r *http.Request, name string, err error) {
+			failures = append(failures, name+": "+err.Error())
+		},
+	})
+
+	query := "SELECT id, email FROM users WHERE created_at >= ? LIMIT ?"
+	err = h.Register(NamedQuery{
+		Name:  "signups",
+		Query: query,
+		Params: []Param{
+			{Name: "since", Type: Time, Required: true},
+			{Name: "limit", Type: Int, Default: int64(100), Validate: func(val interface{}) error {
+				if val.(int64) > 1000 {
+					return errors.New("too large")
+				}
+				return nil
+			}},
+		},
+		Masks: map[string]dbq.Masker{"email": dbq.MaskEmail},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := h.Register(NamedQuery{Name: "purge", Query: "DELETE FROM users"}); err == nil {
+		t.Errorf("expected error when registering a DELETE statement")
+	}
+
+	since := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		accept      string
+		setup       func()
+		code        int
+		contentType string
+		body        string
+	}{
+		{
+			name:   "ndjson",
+			method: "GET",
+			target: "/signups?since=2020-01-01",
+			setup: func() {
+				mock.ExpectQuery(query).WithArgs(since, int64(100)).WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "sally@example.com"))
+			},
+			code:        http.StatusOK,
+			contentType: "application/x-ndjson",
+			body:        `{"email":"s****@example.com","id":1}` + "\n",
+		},
+		{
+			name:   "csv",
+			method: "GET",
+			target: "/signups?since=2020-01-01&limit=5",
+			accept: "text/csv",
+			setup: func() {
+				mock.ExpectQuery(query).WithArgs(since, int64(5)).WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "sally@example.com"))
+			},
+			code:        http.StatusOK,
+			contentType: "text/csv",
+			body:        "id,email\n1,s****@example.com\n",
+		},
+		{
+			name:        "missing param",
+			method:      "GET",
+			target:      "/signups",
+			code:        http.StatusBadRequest,
+			contentType: "application/json",
+			body:        `{"error":"missing parameter: since"}` + "\n",
+		},
+		{
+			name:        "invalid param",
+			method:      "GET",
+			target:      "/signups?since=2020-01-01&limit=5000",
+			code:        http.StatusBadRequest,
+			contentType: "application/json",
+			body:        `{"error":"invalid parameter: limit: too large"}` + "\n",
+		},
+		{
+			name:        "unknown query",
+			method:      "GET",
+			target:      "/users",
+			code:        http.StatusNotFound,
+			contentType: "application/json",
+			body:        `{"error":"unknown query: users"}` + "\n",
+		},
+		{
+			name:        "method",
+			method:      "DELETE",
+			target:      "/signups",
+			code:        http.StatusMethodNotAllowed,
+			contentType: "application/json",
+			body:        `{"error":"method not allowed"}` + "\n",
+		},
+		{
+			name:   "query failed",
+			method: "GET",
+			target: "/signups?since=2020-01-01",
+			setup: func() {
+				mock.ExpectQuery(query).WithArgs(since, int64(100)).WillReturnError(errors.New("connection refused"))
+			},
+			code:        http.StatusInternalServerError,
+			contentType: "application/json",
+			body:        `{"error":"query failed"}` + "\n",
+		},
+	}
+
+	for _, tc := range tests {
+		if tc.setup != nil {
+			tc.setup()
+		}
+
+		req := httptest.NewRequest(tc.method, tc.target, nil)
+		if tc.accept != "" {
+			req.Header.Set("Accept", tc.accept)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != tc.code {
+			t.Errorf("%s: wrong status: expected: %d actual: %d", tc.name, tc.code, rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != tc.contentType {
+			t.Errorf("%s: wrong content type: expected: %s actual: %s", tc.name, tc.contentType, ct)
+		}
+		if rec.Body.String() != tc.body {
+			t.Errorf("%s: wrong body: expected: %q actual: %q", tc.name, tc.body, rec.Body.String())
+		}
+	}
+
+	if len(failures) != 1 || failures[0] != "signups: connection refused" {
+		t.Errorf("wrong failures: %v", failures)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
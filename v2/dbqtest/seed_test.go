@@ -0,0 +1,113 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dbqtest
+
+import (
+	"context"
+	"database/sql/driver"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// anyOf matches an argument using fn.
+type anyOf func(v driver.Value) bool
+
+func (a anyOf) Match(v driver.Value) bool {
+	return a(v)
+}
+
+func TestSeed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("^SELECT column_name, data_type, is_nullable, character_maximum_length").WithArgs("users").WillReturnRows(
+		sqlmock.NewRows([]string{"column_name", "data_type", "is_nullable", "character_maximum_length", "auto"}).
+			AddRow([]byte("id"), []byte("int"), []byte("NO"), nil, []byte("YES")).
+			AddRow([]byte("email"), []byte("varchar"), []byte("NO"), []byte("255"), []byte("NO")).
+			AddRow([]byte("status"), []byte("varchar"), []byte("NO"), []byte("10"), []byte("NO")).
+			AddRow([]byte("bio"), []byte("text"), []byte("YES"), nil, []byte("NO")).
+			AddRow([]byte("created_at"), []byte("datetime"), []byte("NO"), nil, []byte("NO")),
+	)
+
+	email := anyOf(func(v driver.Value) bool {
+		s, ok := v.(string)
+		return ok && strings.HasSuffix(s, "@example.com")
+	})
+	status := anyOf(func(v driver.Value) bool {
+		return v == "active" || v == "banned"
+	})
+	created := anyOf(func(v driver.Value) bool {
+		_, ok := v.(time.Time)
+		return ok
+	})
+
+	mock.ExpectExec("^INSERT INTO `users` \\( `email`,`status`,`bio`,`created_at` \\) VALUES \\( \\?,\\?,\\?,\\? \\),\\( \\?,\\?,\\?,\\? \\)$").
+		WithArgs(email, status, sqlmock.AnyArg(), created, email, status, sqlmock.AnyArg(), created).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("^INSERT INTO `users` \\( `email`,`status`,`bio`,`created_at` \\) VALUES \\( \\?,\\?,\\?,\\? \\)$").
+		WithArgs(email, status, sqlmock.AnyArg(), created).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n, err := Seed(context.Background(), db, "users", &SeedOptions{
+		Rows:      3,
+		BatchSize: 2,
+		Generators: map[string]Generator{
+			"status": OneOf("active", "banned"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("wrong rows inserted: %d", n)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	// No generator
+	mock.ExpectQuery("^SELECT column_name").WithArgs("shapes").WillReturnRows(
+		sqlmock.NewRows([]string{"column_name", "data_type", "is_nullable", "character_maximum_length", "auto"}).
+			AddRow([]byte("geom"), []byte("geometry"), []byte("NO"), nil, []byte("NO")),
+	)
+
+	_, err = Seed(context.Background(), db, "shapes", nil)
+	if err == nil || !strings.Contains(err.Error(), "no generator for column: geom") {
+		t.Errorf("expected missing generator error: got %v", err)
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	if v := truncate(Words(50, 50), 10)(r, 0).(string); len(v) != 10 {
+		t.Errorf("string not truncated: %q", v)
+	}
+
+	if v := Int(5, 7)(r, 0).(int64); v < 5 || v > 7 {
+		t.Errorf("int out of range: %d", v)
+	}
+
+	if v := Sequence(10)(r, 3).(int64); v != 13 {
+		t.Errorf("wrong sequence value: %d", v)
+	}
+
+	if v := UUID()(r, 0).(string); len(v) != 36 || v[14] != '4' {
+		t.Errorf("invalid uuid: %s", v)
+	}
+
+	// Same seed generates the same data
+	a := Name()(rand.New(rand.NewSource(7)), 0)
+	b := Name()(rand.New(rand.NewSource(7)), 0)
+	if a != b {
+		t.Errorf("expected same data: %v %v", a, b)
+	}
+}
@@ -33,6 +33,64 @@ func E(ctx context.Context, db ExecContexter, query string, options *Options, ar
 		ctx = context.Background()
 	}
 
+	if err := checkPrepared(db, query, options); err != nil {
+		return nil, err
+	}
+
+	query, err := applyTenant(ctx, query, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if options != nil && options.Atomic && canBeginTx(db) {
+		o := *options
+		o.Atomic = false
+		res, err := inTx(ctx, db, func(tx interface{}) (interface{}, error) {
+			return E(ctx, tx.(ExecContexter), query, &o, args...)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.(sql.Result), nil
+	}
+
+	if _, ok := db.(*Pgx); !ok && options != nil && options.ServerTimeout > 0 && options.DBType == PostgreSQL {
+		o := *options
+		o.ServerTimeout = 0
+		res, err := pgServerTimeout(ctx, db, options.ServerTimeout, func(db interface{}) (interface{}, error) {
+			return E(ctx, db.(ExecContexter), query, &o, args...)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.(sql.Result), nil
+	}
+
+	if sqlDB, ok := db.(*sql.DB); ok && options != nil && options.KillOnCancel && options.DBType == MySQL {
+		res, err := mysqlKillOnCancel(ctx, sqlDB, func(conn interface{}) (interface{}, error) {
+			return E(ctx, conn.(ExecContexter), query, options, args...)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.(sql.Result), nil
+	}
+
+	query = tagRequestID(ctx, db, query, options)
+
+	if options != nil {
+		query, err = applyHints(query, options.Hints, options.DBType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options != nil && options.ProfileLabels {
+		var restore func()
+		ctx, restore = withProfileLabels(ctx, query)
+		defer restore()
+	}
+
 	for _, v := range args {
 		if arg := reflect.ValueOf(v); arg.Kind() == reflect.Slice {
 			args = FlattenArgs(args...)
@@ -40,31 +98,112 @@ func E(ctx context.Context, db ExecContexter, query string, options *Options, ar
 		}
 	}
 
-	if options == nil || options.RetryPolicy == nil {
-		return db.ExecContext(ctx, query, args...)
+	var (
+		dbtype Database
+		cipher Cipher
+	)
+	if options != nil {
+		dbtype = options.DBType
+		cipher = options.Cipher
+	}
+
+	query, args, err = applyIdentifiers(query, args, dbtype)
+	if err != nil {
+		return nil, err
+	}
+
+	args = encodeEnums(args)
+	args, err = compressArgs(args)
+	if err != nil {
+		return nil, err
 	}
 
-	o := *options
-	o.RetryPolicy = backoff.WithContext(o.RetryPolicy, ctx)
+	args, err = encryptArgs(args, cipher)
+	if err != nil {
+		return nil, err
+	}
+
+	defer watchDeadline(ctx, options, query)()
+
+	if options != nil && options.Tracer != nil {
+		ctx = startSpan(ctx, options.Tracer, "dbq.E", query, dbtype)
+	}
 
 	var res sql.Result
 
-	operation := func() error {
-		var err error
+	if options == nil || options.RetryPolicy == nil {
 		res, err = db.ExecContext(ctx, query, args...)
-		if err != nil {
-			if err == sql.ErrTxDone || err == sql.ErrConnDone || (strings.Contains(err.Error(), "sql: expected") && strings.Contains(err.Error(), "arguments, got")) {
-				return &backoff.PermanentError{err}
+	} else {
+		o := *options
+		o.RetryPolicy = retryPolicy(ctx, o.RetryPolicy)
+
+		operation := func() error {
+			actx, cancel := attemptContext(ctx, &o)
+			defer cancel()
+
+			var err error
+			res, err = db.ExecContext(actx, query, args...)
+			if err != nil {
+				if err == sql.ErrTxDone || err == sql.ErrConnDone || (strings.Contains(err.Error(), "sql: expected") && strings.Contains(err.Error(), "arguments, got")) {
+					return &backoff.PermanentError{err}
+				}
+				return err
 			}
-			return err
+			return nil
+		}
+
+		err = backoff.RetryNotify(operation, o.RetryPolicy, notifyRetry)
+	}
+
+	recordQuery(query, dbtype, err)
+
+	if options != nil && options.Tracer != nil {
+		var attributes map[string]interface{}
+		if err == nil {
+			n, _ := res.RowsAffected()
+			attributes = map[string]interface{}{AttrRowsAffected: n}
+		}
+		options.Tracer.EndSpan(ctx, err, attributes)
+	}
+
+	if options != nil && options.Audit != nil {
+		if auditErr := audit(ctx, options.Audit, query, args, res, err); auditErr != nil && err == nil {
+			return nil, auditErr
 		}
-		return nil
 	}
 
-	err := backoff.Retry(operation, o.RetryPolicy)
 	if err != nil {
 		return nil, err
 	}
 
+	if options != nil && options.ExpectAffected != 0 {
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if err := checkAffected(options.ExpectAffected, n); err != nil {
+			return nil, err
+		}
+	}
+
 	return res, nil
 }
+
+// MustEAffected is a wrapper around the EAffected function. It will panic upon encountering an error.
+// This can erradicate boiler-plate error handing code.
+func MustEAffected(ctx context.Context, db ExecContexter, query string, options *Options, args ...interface{}) int64 {
+	BlvwrN, MRumTN := EAffected(ctx, db, query, options, args...)
+	if MRumTN != nil {
+		panic(MRumTN)
+	}
+	return BlvwrN
+}
+
+// EAffected operates the same as E except it returns the number of rows affected.
+func EAffected(ctx context.Context, db ExecContexter, query string, options *Options, args ...interface{}) (int64, error) {
+	res, err := E(ctx, db, query, options, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -8,6 +8,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"golang.org/x/xerrors"
+	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
@@ -27,6 +29,10 @@ const (
 	MySQL Database = 0
 	// PostgreSQL database
 	PostgreSQL Database = 1
+	// SQLServer database (Microsoft SQL Server)
+	SQLServer Database = 2
+	// SQLite database
+	SQLite Database = 3
 )
 
 // INSERTStmt will generate an INSERT statement. It can be used for bulk inserts.
@@ -48,7 +54,7 @@ func INSERT(tableName string, columns []string, rows int, dbtype ...Database) st
 // For a bulk insert operation, nRows is the number of rows you intend
 // to insert, and nCols is the number of fields per row.
 // For the IN function, set nRows to 1.
-// For PostgreSQL, you can use incr to increment the placeholder starting count.
+// For PostgreSQL and SQLServer, you can use incr to increment the placeholder starting count.
 //
 // NOTE: The function panics if either nCols or nRows is 0.
 //
@@ -63,6 +69,9 @@ func INSERT(tableName string, columns []string, rows int, dbtype ...Database) st
 //  dbq.Ph(3, 2, 6, dbq.PostgreSQL)
 //  // Output: ($7,$8,$9),($10,$11,$12)
 //
+//  dbq.Ph(3, 1, 0, dbq.SQLServer)
+//  // Output: (@p1,@p2,@p3)
+//
 func Ph(nCols, nRows int, incr int, dbtype ...Database) string {
 
 	var typ Database
@@ -78,18 +87,23 @@ func Ph(nCols, nRows int, incr int, dbtype ...Database) string {
 		panic(errors.New("nRows must not be 0"))
 	}
 
-	if typ == MySQL {
+	if typ == MySQL || typ == SQLite {
 		inner := "( " + strings.TrimSuffix(strings.Repeat("?,", nCols), ",") + " ),"
 		return strings.TrimSuffix(strings.Repeat(inner, nRows), ",")
 	}
 
 	var singleValuesStr string
 
+	prefix := "$"
+	if typ == SQLServer {
+		prefix = "@p"
+	}
+
 	varCount := 1 + incr
 	for i := 1; i <= nRows; i++ {
 		singleValuesStr = singleValuesStr + "("
 		for j := 1; j <= nCols; j++ {
-			singleValuesStr = singleValuesStr + fmt.Sprintf("$%d,", varCount)
+			singleValuesStr = singleValuesStr + fmt.Sprintf("%s%d,", prefix, varCount)
 			varCount++
 		}
 		singleValuesStr = strings.TrimSuffix(singleValuesStr, ",") + "),"
@@ -226,6 +240,7 @@ func StdTimeConversionConfig(dbtype ...Database) *StructorConfig {
 // Struct converts the fields of the struct into a slice of values.
 // You can use it to convert a struct into the placeholder arguments required by
 // the Q and E function. tagName is used to indicate the struct tag (default is "dbq").
+// Fields tagged with "encrypted" are wrapped with Encrypted.
 // The function panics if strct is not an actual struct.
 func Struct(strct interface{}, tagName ...string) []interface{} {
 
@@ -264,7 +279,13 @@ func Struct(strct interface{}, tagName ...string) []interface{} {
 			continue
 		}
 
-		if fieldTag == "-" || (strings.HasSuffix(fieldTag, ",omitempty") && reflect.DeepEqual(fieldVal, reflect.Zero(reflect.TypeOf(fieldVal)).Interface())) {
+		_, tagOpts := parseTag(fieldTag)
+		if _, omitempty := tagOpts["omitempty"]; fieldTag == "-" || (omitempty && reflect.DeepEqual(fieldVal, reflect.Zero(reflect.TypeOf(fieldVal)).Interface())) {
+			continue
+		}
+
+		if _, encrypted := tagOpts["encrypted"]; encrypted {
+			out = append(out, Encrypted{fieldVal})
 			continue
 		}
 
@@ -404,3 +425,33 @@ func parseInt64(s string) int64 {
 	n, _ := strconv.ParseInt(s, 10, 64)
 	return n
 }
+
+// parseYear converts a YEAR value to January 1 of the year (UTC).
+func parseYear(s string) time.Time {
+	return time.Date(parseInt(s), time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// parseInteger parses s as an int64. If s overflows an int64 (such as a MySQL BIGINT UNSIGNED value
+// reported without an unsigned ScanType), a uint64 is returned instead. If s overflows a uint64,
+// a *big.Int is returned. When ptr is true, a pointer to the int64 or uint64 is returned.
+func parseInteger(s string, ptr bool) interface{} {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil || !xerrors.Is(err, strconv.ErrRange) {
+		if ptr {
+			return &n
+		}
+		return n
+	}
+
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		if ptr {
+			return &n
+		}
+		return n
+	}
+
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return (*big.Int)(nil)
+	}
+	return n
+}
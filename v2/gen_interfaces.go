@@ -61,6 +61,23 @@ type PostUnmarshaler interface {
 	PostUnmarshal(ctx context.Context, row, total int) error
 }
 
+// Validator allows you to validate each result after unmarshaling (and PostUnmarshal).
+// The ConcreteStruct pointer must implement this interface to make use of this feature.
+// If any result fails validation, Q returns a ValidationErrors.
+//
+// Example:
+//
+//  func (u *user) Validate() error {
+//     if u.Age < 0 {
+//        return errors.New("age must not be negative")
+//     }
+//     return nil
+//  }
+//
+type Validator interface {
+	Validate() error
+}
+
 // ExecContexter is for modifying the database state.
 type ExecContexter interface {
 	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
@@ -86,6 +103,15 @@ type BeginTxer interface {
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
 }
 
+// SQLTx allows for querying, executing statements and beginning transactions.
+// *sql.DB, *sql.Conn, ReplicaSet and StmtCache implement it. Any pool that implements it can be used
+// with Tx, TxWithOptions and the Atomic option. ReplicaSet and StmtCache can only begin a transaction
+// if the pool they wrap implements BeginTxer.
+type SQLTx interface {
+	SQLBasic
+	BeginTxer
+}
+
 type beginTxer2 interface {
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (*rlSql.Tx, error)
 }
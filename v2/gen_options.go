@@ -6,6 +6,8 @@ package dbq
 
 import (
 	"context"
+	"golang.org/x/text/encoding"
+	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/mitchellh/mapstructure"
@@ -69,6 +71,11 @@ type Options struct {
 	// See: https://godoc.org/github.com/mitchellh/mapstructure
 	DecoderConfig *StructorConfig
 
+	// Defaults sets the value of a ConcreteStruct's field when its column is missing from the results or NULL.
+	// The map's key is the column name. Alternatively, a default can be set using the struct tag: `dbq:"col,default=value"`.
+	// Defaults takes precedence over struct tags.
+	Defaults map[string]interface{}
+
 	// SingleResult can be set to true if you know the query will return at most 1 result.
 	// When true, a nil is returned if no result is found. Alternatively, it will return the
 	// single result directly (instead of wrapped in a slice). This makes it easier to
@@ -82,10 +89,301 @@ type Options struct {
 	// ConcurrentPostUnmarshal can be set to true if PostUnmarshal must be called concurrently.
 	ConcurrentPostUnmarshal bool
 
+	// Validator is called to validate each result. If any result fails validation, Q returns a ValidationErrors.
+	// It is called in addition to the Validator interface being implemented by the ConcreteStruct.
+	Validator func(row interface{}) error
+
+	// Cipher is used to encrypt Encrypted args and decrypt the columns of a ConcreteStruct's fields
+	// that are tagged with `dbq:"col,encrypted"`.
+	Cipher Cipher
+
+	// Charset, when set, is the character set of text columns (such as CHAR, VARCHAR and TEXT). Their values are
+	// transcoded to UTF-8. It is intended for legacy databases where the connection's character set can not be converted.
+	// Charset can be set to an encoding provided by the golang.org/x/text/encoding packages, such as
+	// charmap.ISO8859_1 (latin1), charmap.Windows1251 (cp1251) or japanese.ShiftJIS.
+	// This option does nothing if ConcreteStruct implements ScanFaster or RawResults is set.
+	Charset encoding.Encoding
+
+	// TrimChar can be set to true to remove the trailing spaces that pad the values of fixed-width
+	// CHAR(n) columns (such as PostgreSQL's BPCHAR).
+	// This option does nothing if ConcreteStruct implements ScanFaster or RawResults is set.
+	TrimChar bool
+
+	// TrueValues are the values of BOOL columns that are interpreted as true (case-insensitive).
+	// All other values are interpreted as false. If not set, DefaultTrueValues is used.
+	TrueValues []string
+
+	// BoolColumns are the names of columns that must be interpreted as BOOL columns (using TrueValues).
+	// It is useful for databases that store booleans in INT or CHAR columns.
+	// This option does nothing if ConcreteStruct implements ScanFaster or RawResults is set.
+	BoolColumns []string
+
+	// TinyIntAsBool can be set to true for the values of MySQL's TINYINT columns to be returned as a bool.
+	// MySQL conventionally stores booleans as TINYINT(1). Any value other than 0 is true.
+	// This option does nothing if ConcreteStruct implements ScanFaster or RawResults is set.
+	TinyIntAsBool bool
+
+	// YearAsTime can be set to true for the values of MySQL's YEAR columns to be returned as a time.Time
+	// (January 1 of the year in UTC) instead of an int.
+	// This option does nothing if ConcreteStruct implements ScanFaster or RawResults is set.
+	YearAsTime bool
+
+	// Composites registers the struct (not a pointer) that the PostgreSQL composite (row) values of a column are decoded into.
+	// The key is the column name. The composite's attributes are assigned to the struct's exported fields in order.
+	// The values of composite columns that are not registered (and of ROW(...) expressions) are returned as a []interface{}.
+	// This option does nothing if ConcreteStruct implements ScanFaster or RawResults is set.
+	//
+	// Example:
+	//
+	//  type address struct {
+	//     Street string
+	//     City   string
+	//  }
+	//
+	//  opts := &dbq.Options{Composites: map[string]interface{}{"address": address{}}}
+	//
+	Composites map[string]interface{}
+
+	// Lazy adds a *LazyLOB (an io.Reader) to each result for the specified large columns, instead of fetching
+	// their entire values with the results. The key is the name of the column in the results. The column should
+	// not be selected by the query. The value is fetched in chunks by follow-up ranged queries when it is read.
+	// This option does nothing if ConcreteStruct implements ScanFaster or PositionalRows is set.
+	//
+	// Example:
+	//
+	//  opts := &dbq.Options{Lazy: map[string]dbq.LOBSource{"content": {Table: "files", KeyColumn: "id"}}}
+	//  results := dbq.MustQ(ctx, db, "SELECT id, name FROM files", opts)
+	//
+	//  io.Copy(w, results.([]map[string]interface{})[0]["content"].(io.Reader))
+	//
+	Lazy map[string]LOBSource
+
+	// Compression decompresses the values of the specified columns using the Compressor before they are processed.
+	// The key is the column name. Values can be compressed when used as args by wrapping them with Compressed.
+	// This option does nothing if ConcreteStruct implements ScanFaster.
+	Compression map[string]Compressor
+
+	// Mask replaces the values of the specified columns with masked values. The key is the column name.
+	// Masked columns are always returned as strings. Mask is ignored if ConcreteStruct implements ScanFaster.
+	Mask map[string]Masker
+
+	// Audit records every statement executed by E (including failed statements) to an audit trail.
+	// The identity recorded in the ctx by WithIdentity is included.
+	Audit AuditSink
+
+	// Location is the time zone used when the Insert and Update functions set fields tagged
+	// with `dbq:"col,created"` or `dbq:"col,updated"` to the current time. The default is the local time zone.
+	Location *time.Location
+
+	// ExpectAffected, when not 0, is the number of rows E must affect. If a different number of rows
+	// is affected, an *AffectedError is returned. Inside a transaction, the changes can then be rolled back.
+	// Set it to ExpectNoneAffected to assert that no rows are affected.
+	ExpectAffected int64
+
+	// MaxResultBytes, when not 0, is the approximate maximum size of the results that Q will fetch.
+	// If the results are larger, ErrResultTooLarge is returned. This prevents a single bad query
+	// from exhausting the available memory.
+	MaxResultBytes int64
+
+	// OnCancel is called when Q stops fetching results because the ctx was canceled (or its deadline exceeded).
+	// rowsFetched is the number of rows that were fetched.
+	OnCancel func(ctx context.Context, rowsFetched int)
+
+	// OnDeadlinePressure, when set, is called when a query starts with less than DeadlineThreshold remaining
+	// until the ctx's deadline, or completes after consuming more than DeadlineFraction of the remaining time.
+	// It helps diagnose cascading timeout budgets across services.
+	OnDeadlinePressure func(ctx context.Context, p DeadlinePressure)
+
+	// DeadlineThreshold is the minimum time that should remain until the ctx's deadline when a query starts.
+	// See OnDeadlinePressure.
+	DeadlineThreshold time.Duration
+
+	// DeadlineFraction is the maximum fraction (between 0 and 1) of the time remaining until the ctx's deadline
+	// that a query should consume. See OnDeadlinePressure.
+	DeadlineFraction float64
+
+	// OnChecksum, when set, is called by Q with the checksums of the results (see Checksums).
+	// It can't be combined with PositionalRows.
+	OnChecksum func(ctx context.Context, sum ResultChecksum)
+
+	// ChecksumColumns are the columns used to compute the checksums for OnChecksum. If not set, all columns are used.
+	ChecksumColumns []string
+
+	// FailOnDuplicateKey can be set to true for QMap to return a *DuplicateKeyError when
+	// multiple rows have the same key.
+	FailOnDuplicateKey bool
+
+	// Columns, when set, is the list of columns to include in the results. Other columns are discarded.
+	// It is useful when the query (such as one on a third-party view) returns more columns than are required.
+	Columns []string
+
+	// Rename renames the columns of the results. The key is the column name returned by the database
+	// and the value is the new name. When ConcreteStruct is provided, the `dbq` struct tags (as well as the
+	// Defaults option) must refer to the new names. Other options (such as Mask) refer to the original names.
+	Rename map[string]string
+
+	// KeyCase converts the keys of map results to a consistent style. Columns renamed by Rename are not converted.
+	// This option does nothing if ConcreteStruct is provided.
+	KeyCase KeyCase
+
+	// JSONPaths extracts a scalar or subtree from the JSON documents stored in the specified columns.
+	// The key is the column name and the value is a gjson-style path (see JSONPath).
+	// The extracted value replaces the column's value. It is applied before Transforms,
+	// and the Rename option can be used to store it under a different name.
+	// This option does nothing if ConcreteStruct implements ScanFaster, RawResults or StringResults is set.
+	//
+	// Example:
+	//
+	//  JSONPaths: map[string]string{"profile": "address.city"},
+	//  Rename:    map[string]string{"profile": "city"},
+	//
+	JSONPaths map[string]string
+
+	// Transforms modifies the values of the specified columns. The key is the column name (before Rename is applied).
+	// For map results, the function is called after the value has been converted to its Go type.
+	// When ConcreteStruct is provided, the function is called before the value is decoded into the struct's field
+	// (the value is typically a string). NULL values are passed as nil or a nil pointer.
+	// This option does nothing if ConcreteStruct implements ScanFaster, RawResults or StringResults is set.
+	//
+	// Example:
+	//
+	//  Transforms: map[string]func(interface{}) (interface{}, error){
+	//     "email": func(v interface{}) (interface{}, error) {
+	//        if s, ok := v.(*string); ok && s != nil {
+	//           return strings.ToLower(*s), nil
+	//        }
+	//        return v, nil
+	//     },
+	//  }
+	//
+	Transforms map[string]func(interface{}) (interface{}, error)
+
 	// RawResults can be set to true for results to be returned unprocessed ([]byte).
 	// This option does nothing if ConcreteStruct is provided.
 	RawResults bool
 
+	// PositionalRows can be set to true for results to be returned as [][]interface{}, where the values of each
+	// row are in the same order as the columns returned by the database. It is faster than returning maps,
+	// and convenient for table renderers and spreadsheet writers. Mask and RawResults are supported, but an error
+	// is returned if it is combined with an option that refers to the results by key (Columns, Rename, KeyCase,
+	// JSONPaths, Transforms and StringResults) or with OnChecksum.
+	// This option does nothing if ConcreteStruct is provided.
+	PositionalRows bool
+
+	// StringResults can be set to true for results to be returned as []map[string]string, where each value
+	// is the string representation returned by the database. NULL values are set to NullString.
+	// It is useful for tooling (such as CSV processing and templating) that does not need typed values.
+	// This option does nothing if ConcreteStruct is provided or RawResults is set.
+	StringResults bool
+
+	// NullString is the value used for NULL columns when StringResults is set. The default is an empty string.
+	NullString string
+
+	// FailOnUnknownType can be set to true for Q to return an *UnknownTypeError when a column's
+	// database type is not recognized, instead of assuming it is a string.
+	// This option does nothing if ConcreteStruct is provided or RawResults is set.
+	FailOnUnknownType bool
+
+	// FallbackHandler can be set to convert the values of columns whose database type is not recognized.
+	// raw is nil when the value is NULL. raw is only valid until FallbackHandler returns, so it must be copied if retained.
+	// It takes precedence over FailOnUnknownType.
+	// This option does nothing if ConcreteStruct is provided or RawResults is set.
+	//
+	// Example:
+	//
+	//  func(colName, dbType string, raw []byte, nullable bool) (interface{}, error) {
+	//     switch dbType {
+	//     case "UUID":
+	//        return uuid.ParseBytes(raw)
+	//     }
+	//     return string(raw), nil
+	//  }
+	//
+	FallbackHandler func(colName, dbType string, raw []byte, nullable bool) (interface{}, error)
+
+	// DBType sets the database being used. The default is MySQL.
+	// It is used by features that require database-specific syntax.
+	DBType Database
+
+	// SkipLocked can be set to true for QForUpdate to skip rows that are
+	// already locked by another transaction instead of waiting for them.
+	SkipLocked bool
+
+	// TenantResolver can be set to determine the tenant that replaces TenantPlaceholder in the query.
+	// If not set, the tenant is obtained from the ctx (see WithTenant).
+	TenantResolver func(ctx context.Context) (string, error)
+
+	// ServerTimeout can be set for the database server to abort the query if it takes too long,
+	// even if the client has stopped waiting for it. For PostgreSQL, statement_timeout is set
+	// for the duration of a transaction (which is created if db is not already a transaction).
+	// An error is returned if db can't begin one (e.g. AllowList or PreparedStmt). It is ignored for Pgx.
+	// For MySQL, the MAX_EXECUTION_TIME optimizer hint is added to SELECT statements.
+	// It is ignored for other databases.
+	ServerTimeout time.Duration
+
+	// TagRequestID can be set to prepend a comment containing the request ID (see WithRequestID) to the query.
+	// The ID then appears in the database's logs (e.g. the slow query log or pg_stat_activity).
+	//
+	// NOTE: The query is different for each request. The comment is therefore not added when db is a
+	// StmtCache (or a prepared statement), and ColumnCache and AllowList ignore it. The ID is still recorded
+	// in each AuditEntry.
+	TagRequestID bool
+
+	// ProfileLabels can be set to add runtime/pprof labels (see ProfileLabelQuery and ProfileLabelOperation)
+	// while the query is executed. CPU and goroutine profiles then attribute the time spent inside
+	// the driver to specific queries.
+	ProfileLabels bool
+
+	// Tracer can be set to record each statement as a span in a tracing system.
+	Tracer Tracer
+
+	// Hints can be set to add optimizer hints to the query using the syntax of DBType.
+	// For MySQL, they are added in a /*+ ... */ comment directly after the verb.
+	// For PostgreSQL, they are added in a /*+ ... */ comment at the start of the query (see pg_hint_plan).
+	// For SQLServer, they are added in an OPTION clause at the end of the query. They are ignored for SQLite.
+	// Each hint may only contain identifiers, numbers, whitespace, balanced parentheses and the characters _,.@=#$.
+	// Otherwise ErrInvalidHint is returned.
+	//
+	// Example:
+	//
+	//  Hints: []string{"INDEX(users idx_email)", "NO_BNL(users)"}
+	//
+	Hints []string
+
+	// ColumnCache can be set to cache the column metadata of the query (see ColumnCache).
+	ColumnCache *ColumnCache
+
+	// DecodeWorkers is the number of goroutines that decode rows into ConcreteStruct. When greater than 1,
+	// rows are scanned by the calling goroutine while the workers decode them concurrently. The order of the
+	// results is preserved. It is useful when decoding is expensive, such as for wide rows or costly decode hooks.
+	//
+	// NOTE: The DecodeHook of DecoderConfig must be safe for concurrent use.
+	DecodeWorkers int
+
+	// FetchSize can be set to fetch the results from the database in batches of FetchSize rows.
+	// For PostgreSQL, the query is declared as a cursor and the rows are fetched using FETCH. If db is not
+	// a transaction, one is used. This prevents the driver from buffering gigantic result sets, which
+	// is most useful with QSpill.
+	//
+	// NOTE: For MySQL, the driver already streams the results from the server as they are read, so it is not required.
+	// For other databases, FetchSize is ignored.
+	FetchSize int
+
+	// Atomic can be set to execute the statement within a transaction that is committed if it succeeds
+	// and rolled back otherwise. It is useful for statements consisting of multiple statements, and helpers that
+	// execute multiple statements (such as ChunkedExec) which must be applied atomically.
+	//
+	// NOTE: It has no effect if db is already a transaction.
+	Atomic bool
+
+	// KillOnCancel can be set to kill the query on the server when the ctx is canceled or its deadline is exceeded.
+	// For MySQL, canceling the ctx only closes the connection, while the server continues executing the query.
+	// When set, the query is executed on a reserved connection and KILL QUERY is issued on a separate one.
+	//
+	// NOTE: It is only supported for MySQL when db is a *sql.DB. It is not required when using
+	// the mysql-go package (https://github.com/rocketlaunchr/mysql-go).
+	KillOnCancel bool
+
 	// RetryPolicy can be set if you want to retry the query in the event of failure.
 	//
 	// Example:
@@ -93,6 +391,27 @@ type Options struct {
 	//  dbq.ExponentialRetryPolicy(60 * time.Second, 3)
 	//
 	RetryPolicy backoff.BackOff
+
+	// RetryDeadlineFraction is the fraction of the time remaining until the ctx's deadline that each attempt may
+	// consume when RetryPolicy is set. For example, with 0.5 the first attempt may use half of the remaining time,
+	// the second attempt half of what is then left, and so on. This prevents the first attempt from consuming
+	// the entire deadline. The default allows each attempt to consume all the remaining time.
+	//
+	// NOTE: Regardless of RetryDeadlineFraction, a retry is skipped if the delay before it reaches the deadline.
+	// For Q, the attempt includes fetching the results.
+	RetryDeadlineFraction float64
+
+	// Timing can be set to measure the timing breakdown of Q (see ResultMeta). It is reported to the Tracer.
+	// Use QMeta to obtain it directly.
+	//
+	// NOTE: It is ignored when db is a Pgx.
+	Timing bool
+
+	// meta is set by QMeta to obtain the timing breakdown.
+	meta *ResultMeta
+
+	// spill is set by QSpill so that results are added to a SpillBuffer instead of being kept in memory.
+	spill *SpillBuffer
 }
 
 // Q is a convenience function that calls dbq.Q.
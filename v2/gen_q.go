@@ -54,10 +54,86 @@ func Q(ctx context.Context, db interface{}, query string, options *Options, args
 		o = *options
 
 		if o.RetryPolicy != nil {
-			o.RetryPolicy = backoff.WithContext(o.RetryPolicy, ctx)
+			o.RetryPolicy = retryPolicy(ctx, o.RetryPolicy)
 		}
 	}
 
+	if err := checkPrepared(db, query, options); err != nil {
+		return nil, err
+	}
+
+	if err := checkPositionalRows(&o); err != nil {
+		return nil, err
+	}
+
+	query, err := applyTenant(ctx, query, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Atomic && canBeginTx(db) {
+		o2 := *options
+		o2.Atomic = false
+		return inTx(ctx, db, func(tx interface{}) (interface{}, error) {
+			return Q(ctx, tx, query, &o2, args...)
+		})
+	}
+
+	if o.ServerTimeout > 0 {
+		switch o.DBType {
+		case MySQL:
+			query = mysqlServerTimeout(query, o.ServerTimeout)
+		case PostgreSQL:
+			if _, ok := db.(*Pgx); ok {
+				break
+			}
+			o2 := *options
+			o2.ServerTimeout = 0
+			return pgServerTimeout(ctx, db, o.ServerTimeout, func(db interface{}) (interface{}, error) {
+				return Q(ctx, db, query, &o2, args...)
+			})
+		}
+	}
+
+	if sqlDB, ok := db.(*sql.DB); ok && o.KillOnCancel && o.DBType == MySQL {
+
+		o2 := *options
+		o2.ServerTimeout = 0
+		o2.KillOnCancel = false
+		return mysqlKillOnCancel(ctx, sqlDB, func(conn interface{}) (interface{}, error) {
+			return Q(ctx, conn, query, &o2, args...)
+		})
+	}
+
+	if o.FetchSize > 0 && o.DBType == PostgreSQL {
+		if rs, ok := db.(*ReplicaSet); ok {
+
+			db = rs.route(query)
+		}
+
+		if canBeginTx(db) {
+
+			return inTx(ctx, db, func(tx interface{}) (interface{}, error) {
+				return Q(ctx, tx, query, options, args...)
+			})
+		}
+	}
+
+	query = tagRequestID(ctx, db, query, options)
+
+	if options != nil {
+		query, err = applyHints(query, options.Hints, options.DBType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options != nil && options.ProfileLabels {
+		var restore func()
+		ctx, restore = withProfileLabels(ctx, query)
+		defer restore()
+	}
+
 	defer func() {
 		if rErr == nil && o.SingleResult {
 			rows := reflect.ValueOf(out)
@@ -77,9 +153,26 @@ func Q(ctx context.Context, db interface{}, query string, options *Options, args
 		}
 	}
 
+	query, args, err = applyIdentifiers(query, args, o.DBType)
+	if err != nil {
+		return nil, err
+	}
+
+	args = encodeEnums(args)
+	args, err = compressArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err = encryptArgs(args, o.Cipher)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		outStruct     interface{}
 		outMap        = []map[string]interface{}{}
+		outRows       = [][]interface{}{}
 		scanFast      bool
 		postUnmarshal bool
 	)
@@ -95,12 +188,71 @@ func Q(ctx context.Context, db interface{}, query string, options *Options, args
 	}
 
 	var (
-		rows      rows
-		err       error
-		operation func() error
+		tags structTags
+		dec  *structDecoder
+	)
+	if o.ConcreteStruct != nil && !scanFast {
+		tags = parseStructTags(reflect.TypeOf(o.ConcreteStruct), o.Defaults)
+		if len(tags.encrypted) > 0 && o.Cipher == nil {
+			return nil, ErrNoCipher
+		}
+
+		dec, err = newStructDecoder(&o)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if stmt, ok := db.(*sql.Stmt); ok {
+		db = Prepared(stmt)
+	}
+
+	defer watchDeadline(ctx, &o, query)()
+
+	timing := newQueryTiming(&o)
+
+	if o.Tracer != nil {
+		ctx = startSpan(ctx, o.Tracer, "dbq.Q", query, o.DBType)
+		spanCtx := ctx
+		defer func() {
+			var attributes map[string]interface{}
+			if rErr == nil {
+				attributes = map[string]interface{}{AttrRows: resultCount(out)}
+				if timing != nil {
+					timing.attributes(attributes)
+				}
+			}
+			o.Tracer.EndSpan(spanCtx, rErr, attributes)
+		}()
+	}
+
+	if timing != nil {
+		timing.start = time.Now()
+	}
+
+	if p, ok := db.(*Pgx); ok {
+		res, err := p.q(ctx, query, &o, args, tags)
+		if err != nil {
+			return nil, err
+		}
+		if timing != nil {
+
+			timing.meta.Rows = resultCount(res)
+			timing.finish(&o)
+		}
+		return res, nil
+	}
+
+	var (
+		rows          rows
+		operation     func() error
+		cancelAttempt = func() {}
 	)
+	defer func() { cancelAttempt() }()
 
-	if o.RetryPolicy == nil {
+	if cdb, ok := db.(SQLBasic); ok && o.FetchSize > 0 && o.DBType == PostgreSQL {
+		rows, err = openCursor(ctx, cdb, query, o.FetchSize, args)
+	} else if o.RetryPolicy == nil {
 		switch db := db.(type) {
 		case QueryContexter:
 			rows, err = db.QueryContext(ctx, query, args...)
@@ -113,117 +265,323 @@ func Q(ctx context.Context, db interface{}, query string, options *Options, args
 		switch db := db.(type) {
 		case QueryContexter:
 			operation = func() error {
-				rows, err = db.QueryContext(ctx, query, args...)
+				actx, cancel := attemptContext(ctx, &o)
+				rows, err = db.QueryContext(actx, query, args...)
 				if err != nil {
+					cancel()
 					if err == sql.ErrTxDone || err == sql.ErrConnDone || (strings.Contains(err.Error(), "sql: expected") && strings.Contains(err.Error(), "arguments, got")) {
 						return &backoff.PermanentError{err}
 					}
 					return err
 				}
+				cancelAttempt = cancel
 				return nil
 			}
 		case queryContexter2:
 			operation = func() error {
-				rows, err = db.QueryContext(ctx, query, args...)
+				actx, cancel := attemptContext(ctx, &o)
+				rows, err = db.QueryContext(actx, query, args...)
 				if err != nil {
+					cancel()
 					if err == sql.ErrTxDone || err == sql.ErrConnDone || (strings.Contains(err.Error(), "sql: expected") && strings.Contains(err.Error(), "arguments, got")) {
 						return &backoff.PermanentError{err}
 					}
 					return err
 				}
+				cancelAttempt = cancel
 				return nil
 			}
 		default:
 			panic(fmt.Sprintf("interface conversion: %T is not dbq.QueryContexter: missing method: QueryContext", db))
 		}
 
-		err = backoff.Retry(operation, o.RetryPolicy)
+		err = backoff.RetryNotify(operation, o.RetryPolicy, notifyRetry)
 	}
 
+	recordQuery(query, o.DBType, err)
+
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	cols, err := rows.ColumnTypes()
+	if timing != nil {
+		rows = timing.wrap(rows)
+	}
+
+	var (
+		cols     []*sql.ColumnType
+		colTypes []string
+	)
+	if o.ColumnCache != nil {
+		cols, colTypes, err = o.ColumnCache.columnTypes(query, rows)
+	} else {
+		cols, colTypes, err = columnTypes(rows)
+	}
 	if err != nil {
 		return nil, err
 	}
 	totalColumns := len(cols)
 
+	var (
+		resultBytes int64
+		rowCount    int
+		proj        = newProjection(&o)
+		direct      *directScan
+		pipe        *decodePipeline
+	)
+
+	if dec != nil {
+		if o.DecodeWorkers > 1 {
+			pipe, err = newDecodePipeline(&o, func(res interface{}) error {
+				if o.spill != nil {
+					return o.spill.add(ctx, res)
+				}
+				outStruct = reflect.Append(outStruct.(reflect.Value), reflect.ValueOf(res))
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			defer pipe.close()
+		} else {
+			direct = newDirectScan(&o, tags, cols, colTypes)
+		}
+	}
+
 	for rows.Next() {
-		var rowData []interface{}
+
+		if rowCount%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				if o.OnCancel != nil {
+					o.OnCancel(ctx, rowCount)
+				}
+				return nil, err
+			}
+		}
+		rowCount++
 
 		if scanFast {
 			res := reflect.New(reflect.TypeOf(o.ConcreteStruct)).Interface()
-			if err := rows.Scan(res.(ScanFaster).ScanFast()...); err != nil {
+			dest := res.(ScanFaster).ScanFast()
+			if err := rows.Scan(dest...); err != nil {
 				return nil, err
 			}
-			outStruct = reflect.Append(outStruct.(reflect.Value), reflect.ValueOf(res))
+			if o.MaxResultBytes > 0 {
+				resultBytes += approxSize(dest)
+				if resultBytes > o.MaxResultBytes {
+					return nil, ErrResultTooLarge
+				}
+			}
+			if o.spill != nil {
+				if err := o.spill.add(ctx, res); err != nil {
+					return nil, err
+				}
+			} else {
+				outStruct = reflect.Append(outStruct.(reflect.Value), reflect.ValueOf(res))
+			}
 			continue
-		} else {
-			rowData = make([]interface{}, totalColumns)
-			for i := range rowData {
-				rowData[i] = &sql.RawBytes{}
+		}
+
+		if direct != nil {
+			if res, dest, ok := direct.scan(rows); ok {
+				if o.MaxResultBytes > 0 {
+					resultBytes += approxSize(dest)
+					if resultBytes > o.MaxResultBytes {
+						return nil, ErrResultTooLarge
+					}
+				}
+				if o.spill != nil {
+					if err := o.spill.add(ctx, res); err != nil {
+						return nil, err
+					}
+				} else {
+					outStruct = reflect.Append(outStruct.(reflect.Value), reflect.ValueOf(res))
+				}
+				continue
 			}
-			if err := rows.Scan(rowData...); err != nil {
-				return nil, err
+
+		}
+
+		rowData := make([]interface{}, totalColumns)
+		for i := range rowData {
+			rowData[i] = &sql.RawBytes{}
+		}
+		if err := rows.Scan(rowData...); err != nil {
+			return nil, err
+		}
+		if err := decompressColumns(&o, cols, rowData); err != nil {
+			return nil, err
+		}
+		if o.MaxResultBytes > 0 {
+			resultBytes += approxSize(rowData)
+			if resultBytes > o.MaxResultBytes {
+				return nil, ErrResultTooLarge
+			}
+		}
+
+		if o.PositionalRows && o.ConcreteStruct == nil {
+			row := make([]interface{}, totalColumns)
+			for colID, elem := range rowData {
+				raw := elem.(*sql.RawBytes)
+
+				if mask := o.Mask[cols[colID].Name()]; mask != nil && *raw != nil {
+					if o.RawResults {
+						row[colID] = []byte(mask(string(*raw)))
+					} else {
+						text, err := decodeText(&o, cols[colID].Name(), colTypes[colID], *raw)
+						if err != nil {
+							return nil, err
+						}
+						row[colID] = mask(string(text))
+					}
+					continue
+				}
+
+				if o.RawResults {
+					cpy := make([]byte, len(*raw))
+					copy(cpy, []byte(*raw))
+					row[colID] = cpy
+					continue
+				}
+
+				val, err := convertColumn(&o, cols[colID], colTypes[colID], *raw)
+				if err != nil {
+					return nil, err
+				}
+				row[colID] = val
 			}
+			outRows = append(outRows, row)
+			continue
 		}
 
 		vals := map[string]interface{}{}
 		if o.ConcreteStruct != nil {
 			for colID, elem := range rowData {
 				fieldName := cols[colID].Name()
+				if !proj.include(fieldName) {
+					continue
+				}
+
 				raw := elem.(*sql.RawBytes)
 				if *raw == nil {
 					vals[fieldName] = nil
+					continue
+				}
+
+				var val string
+				if tags.encrypted[fieldName] {
+					plaintext, err := o.Cipher.Decrypt(*raw)
+					if err != nil {
+						return nil, xerrors.Errorf("dbq.Decrypt @ column %s: %w", fieldName, err)
+					}
+					val = string(plaintext)
 				} else {
-					vals[fieldName] = string(*raw)
+					text, err := decodeText(&o, fieldName, colTypes[colID], *raw)
+					if err != nil {
+						return nil, err
+					}
+					val = string(text)
 				}
-			}
 
-			res := reflect.New(reflect.TypeOf(o.ConcreteStruct)).Interface()
-			if o.DecoderConfig != nil {
-				dc := &mapstructure.DecoderConfig{
-					DecodeHook:       o.DecoderConfig.DecodeHook,
-					ZeroFields:       true,
-					TagName:          "dbq",
-					WeaklyTypedInput: o.DecoderConfig.WeaklyTypedInput,
-					Result:           res,
-				}
-				decoder, err := mapstructure.NewDecoder(dc)
-				if err != nil {
-					return nil, err
+				if isBoolColumn(&o, fieldName) {
+					vals[fieldName] = parseBool(&o, val)
+					continue
 				}
-				err = decoder.Decode(vals)
-				if err != nil {
-					return nil, err
+
+				if o.TinyIntAsBool && colTypes[colID] == "TINYINT" {
+					vals[fieldName] = val != "0"
+					continue
 				}
-			} else {
-				dc := &mapstructure.DecoderConfig{
-					ZeroFields:       true,
-					TagName:          "dbq",
-					WeaklyTypedInput: true,
-					Result:           res,
+
+				if o.YearAsTime && colTypes[colID] == "YEAR" {
+					vals[fieldName] = parseYear(val)
+					continue
 				}
-				decoder, err := mapstructure.NewDecoder(dc)
-				if err != nil {
+
+				if e := lookupEnum(fieldName, colTypes[colID]); e != nil {
+					v, err := e.value(fieldName, val)
+					if err != nil {
+						return nil, err
+					}
+					vals[fieldName] = v
+					continue
+				}
+
+				if isComposite(&o, fieldName, colTypes[colID]) {
+					v, err := decodeComposite(&o, fieldName, val)
+					if err != nil {
+						return nil, err
+					}
+					vals[fieldName] = v
+					continue
+				}
+
+				if mask := o.Mask[fieldName]; mask != nil {
+					val = mask(val)
+				}
+				vals[fieldName] = val
+			}
+
+			if err := addLazyLOBs(ctx, db, &o, vals); err != nil {
+				return nil, err
+			}
+			if err := applyJSONPaths(&o, vals); err != nil {
+				return nil, err
+			}
+			if err := applyTransforms(&o, vals); err != nil {
+				return nil, err
+			}
+			proj.apply(vals)
+
+			for col, def := range tags.defaults {
+				if val, exists := vals[col]; !exists || val == nil {
+					vals[col] = def
+				}
+			}
+
+			if pipe != nil {
+				if err := pipe.submit(vals); err != nil {
 					return nil, err
 				}
-				err = decoder.Decode(vals)
-				if err != nil {
+				continue
+			}
+
+			res, err := dec.decode(vals)
+			if err != nil {
+				return nil, err
+			}
+			if o.spill != nil {
+				if err := o.spill.add(ctx, res); err != nil {
 					return nil, err
 				}
+			} else {
+				outStruct = reflect.Append(outStruct.(reflect.Value), reflect.ValueOf(res))
 			}
-			outStruct = reflect.Append(outStruct.(reflect.Value), reflect.ValueOf(res))
 			continue
 		}
 
 		for colID, elem := range rowData {
 			fieldName := cols[colID].Name()
+			if !proj.include(fieldName) {
+				continue
+			}
+
 			raw := elem.(*sql.RawBytes)
 
+			if mask := o.Mask[fieldName]; mask != nil && *raw != nil {
+				if o.RawResults {
+					vals[fieldName] = []byte(mask(string(*raw)))
+				} else {
+					text, err := decodeText(&o, fieldName, colTypes[colID], *raw)
+					if err != nil {
+						return nil, err
+					}
+					vals[fieldName] = mask(string(text))
+				}
+				continue
+			}
+
 			if o.RawResults {
 				cpy := make([]byte, len(*raw))
 				copy(cpy, []byte(*raw))
@@ -231,302 +589,524 @@ func Q(ctx context.Context, db interface{}, query string, options *Options, args
 				continue
 			}
 
-			colType := cols[colID].DatabaseTypeName()
-			nullable, hasNullableInfo := cols[colID].Nullable()
+			if o.StringResults {
+				if *raw == nil {
+					vals[fieldName] = o.NullString
+				} else {
+					text, err := decodeText(&o, fieldName, colTypes[colID], *raw)
+					if err != nil {
+						return nil, err
+					}
+					vals[fieldName] = string(text)
+				}
+				continue
+			}
 
-			var val *string
+			val, err := convertColumn(&o, cols[colID], colTypes[colID], *raw)
+			if err != nil {
+				return nil, err
+			}
+			vals[fieldName] = val
+		}
 
-			if *raw != nil {
-				val = &[]string{string(*raw)}[0]
+		if err := addLazyLOBs(ctx, db, &o, vals); err != nil {
+			return nil, err
+		}
+
+		if !o.RawResults && !o.StringResults {
+			if err := applyJSONPaths(&o, vals); err != nil {
+				return nil, err
 			}
+			if err := applyTransforms(&o, vals); err != nil {
+				return nil, err
+			}
+		}
+		proj.apply(vals)
 
-			switch colType {
-			case "NULL":
-				vals[fieldName] = nil
-			case "CHAR", "VARCHAR", "TEXT", "NVARCHAR", "MEDIUMTEXT", "LONGTEXT":
-				if nullable || !hasNullableInfo {
-					vals[fieldName] = val
-				} else {
-					if hasNullableInfo {
+		if o.spill != nil {
+			if err := o.spill.add(ctx, vals); err != nil {
+				return nil, err
+			}
+		} else {
+			outMap = append(outMap, vals)
+		}
+	}
 
-						vals[fieldName] = *val
-					}
-				}
-			case "FLOAT", "DOUBLE", "DECIMAL", "NUMERIC", "FLOAT4", "FLOAT8":
-				if nullable || !hasNullableInfo {
-					if val == nil {
-						vals[fieldName] = (*float64)(nil)
-					} else {
-						f, _ := strconv.ParseFloat(*val, 64)
-						vals[fieldName] = &f
-					}
-				} else {
-					if hasNullableInfo {
+	err = rows.Close()
+	if err != nil {
+		return nil, err
+	}
 
-						f, _ := strconv.ParseFloat(*val, 64)
-						vals[fieldName] = f
-					}
-				}
-			case "INT", "TINYINT", "INT2", "INT4", "INT8", "MEDIUMINT", "SMALLINT", "BIGINT":
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-				switch cols[colID].ScanType().Kind() {
-				case reflect.Uint:
-					if nullable || !hasNullableInfo {
-						if val == nil {
-							vals[fieldName] = (*uint)(nil)
-						} else {
-							vals[fieldName] = parseUintP(*val)
-						}
-					} else {
-						if hasNullableInfo {
+	if pipe != nil {
+		if err := pipe.close(); err != nil {
+			return nil, err
+		}
+	}
 
-							vals[fieldName] = parseUint(*val)
-						}
-					}
-				case reflect.Uint8:
-					if nullable || !hasNullableInfo {
-						if val == nil {
-							vals[fieldName] = (*uint8)(nil)
-						} else {
-							vals[fieldName] = parseUint8P(*val)
-						}
-					} else {
-						if hasNullableInfo {
+	out, err = finishQ(ctx, &o, outStruct, outMap, outRows, postUnmarshal)
+	if err != nil {
+		return nil, err
+	}
 
-							vals[fieldName] = parseUint8(*val)
-						}
-					}
-				case reflect.Uint16:
-					if nullable || !hasNullableInfo {
-						if val == nil {
-							vals[fieldName] = (*uint16)(nil)
-						} else {
-							vals[fieldName] = parseUint16P(*val)
-						}
-					} else {
-						if hasNullableInfo {
+	if timing != nil {
+		timing.finish(&o)
+	}
+	return out, nil
+}
 
-							vals[fieldName] = parseUint16(*val)
-						}
-					}
-				case reflect.Uint32:
-					if nullable || !hasNullableInfo {
-						if val == nil {
-							vals[fieldName] = (*uint32)(nil)
-						} else {
-							vals[fieldName] = parseUint32P(*val)
-						}
-					} else {
-						if hasNullableInfo {
+// checkPositionalRows returns an error if the PositionalRows option is combined with an option
+// that refers to the results by key.
+func checkPositionalRows(o *Options) error {
+	if !o.PositionalRows || o.ConcreteStruct != nil {
+		return nil
+	}
 
-							vals[fieldName] = parseUint32(*val)
-						}
-					}
-				case reflect.Uint64:
-					if nullable || !hasNullableInfo {
-						if val == nil {
-							vals[fieldName] = (*uint64)(nil)
-						} else {
-							vals[fieldName] = parseUint64P(*val)
-						}
-					} else {
-						if hasNullableInfo {
+	var option string
+	switch {
+	case len(o.Columns) > 0:
+		option = "Columns"
+	case len(o.Rename) > 0:
+		option = "Rename"
+	case o.KeyCase != KeyCaseAsIs:
+		option = "KeyCase"
+	case len(o.JSONPaths) > 0:
+		option = "JSONPaths"
+	case len(o.Transforms) > 0:
+		option = "Transforms"
+	case o.StringResults:
+		option = "StringResults"
+	case o.OnChecksum != nil:
+		option = "OnChecksum"
+	default:
+		return nil
+	}
+	return fmt.Errorf("%s option is not supported with PositionalRows", option)
+}
 
-							vals[fieldName] = parseUint64(*val)
-						}
-					}
-				case reflect.Int:
-					if nullable || !hasNullableInfo {
-						if val == nil {
-							vals[fieldName] = (*int)(nil)
-						} else {
-							vals[fieldName] = parseIntP(*val)
-						}
-					} else {
-						if hasNullableInfo {
+// convertColumn converts the raw value of a column to a Go type based on the column's database type (colType).
+func convertColumn(o *Options, col *sql.ColumnType, colType string, raw sql.RawBytes) (interface{}, error) {
+	fieldName := col.Name()
+	nullable, hasNullableInfo := col.Nullable()
 
-							vals[fieldName] = parseInt(*val)
-						}
-					}
-				case reflect.Int8:
-					if nullable || !hasNullableInfo {
-						if val == nil {
-							vals[fieldName] = (*int8)(nil)
-						} else {
-							vals[fieldName] = parseInt8P(*val)
-						}
-					} else {
-						if hasNullableInfo {
+	raw, err := decodeText(o, fieldName, colType, raw)
+	if err != nil {
+		return nil, err
+	}
 
-							vals[fieldName] = parseInt8(*val)
-						}
-					}
-				case reflect.Int16:
-					if nullable || !hasNullableInfo {
-						if val == nil {
-							vals[fieldName] = (*int16)(nil)
-						} else {
-							vals[fieldName] = parseInt16P(*val)
-						}
-					} else {
-						if hasNullableInfo {
+	var val *string
 
-							vals[fieldName] = parseInt16(*val)
-						}
-					}
-				case reflect.Int32:
-					if nullable || !hasNullableInfo {
-						if val == nil {
-							vals[fieldName] = (*int32)(nil)
-						} else {
-							vals[fieldName] = parseInt32P(*val)
-						}
-					} else {
-						if hasNullableInfo {
+	if raw != nil {
+		val = &[]string{string(raw)}[0]
+	}
 
-							vals[fieldName] = parseInt32(*val)
-						}
-					}
-				case reflect.Int64:
-					if nullable || !hasNullableInfo {
-						if val == nil {
-							vals[fieldName] = (*int64)(nil)
-						} else {
-							vals[fieldName] = parseInt64P(*val)
-						}
-					} else {
-						if hasNullableInfo {
+	if isBoolColumn(o, fieldName) {
+		colType = "BOOL"
+	}
 
-							vals[fieldName] = parseInt64(*val)
-						}
-					}
-				default:
-					if nullable || !hasNullableInfo {
-						if val == nil {
-							vals[fieldName] = (*int64)(nil)
-						} else {
-							vals[fieldName] = parseInt64P(*val)
-						}
-					} else {
-						if hasNullableInfo {
+	if o.TinyIntAsBool && colType == "TINYINT" {
+		if nullable || !hasNullableInfo {
+			if val == nil {
+				return (*bool)(nil), nil
+			}
+			return &[]bool{*val != "0"}[0], nil
+		}
+		return *val != "0", nil
+	}
 
-							vals[fieldName] = parseInt64(*val)
-						}
-					}
+	if e := lookupEnum(fieldName, colType); e != nil {
+		if val == nil {
+			if nullable || !hasNullableInfo {
+				return reflect.Zero(reflect.PtrTo(e.typ)).Interface(), nil
+			}
+			return nil, nil
+		}
+
+		v, err := e.value(fieldName, *val)
+		if err != nil {
+			return nil, err
+		}
+		if nullable || !hasNullableInfo {
+			ptr := reflect.New(e.typ)
+			ptr.Elem().Set(reflect.ValueOf(v))
+			return ptr.Interface(), nil
+		}
+		return v, nil
+	}
+
+	if isComposite(o, fieldName, colType) {
+		if val == nil {
+			return nil, nil
+		}
+		return decodeComposite(o, fieldName, *val)
+	}
+
+	switch colType {
+	case "NULL":
+		return nil, nil
+	case "CHAR", "VARCHAR", "TEXT", "NVARCHAR", "MEDIUMTEXT", "LONGTEXT", "BPCHAR", "NCHAR":
+		if nullable || !hasNullableInfo {
+			return val, nil
+		} else {
+			if hasNullableInfo {
+
+				return *val, nil
+			}
+		}
+	case "FLOAT", "DOUBLE", "DECIMAL", "NUMERIC", "FLOAT4", "FLOAT8":
+		if nullable || !hasNullableInfo {
+			if val == nil {
+				return (*float64)(nil), nil
+			} else {
+				f, _ := strconv.ParseFloat(*val, 64)
+				return &f, nil
+			}
+		} else {
+			if hasNullableInfo {
+
+				f, _ := strconv.ParseFloat(*val, 64)
+				return f, nil
+			}
+		}
+	case "INT", "TINYINT", "INT2", "INT4", "INT8", "MEDIUMINT", "SMALLINT", "BIGINT":
+
+		switch col.ScanType().Kind() {
+		case reflect.Uint:
+			if nullable || !hasNullableInfo {
+				if val == nil {
+					return (*uint)(nil), nil
+				} else {
+					return parseUintP(*val), nil
 				}
-			case "BOOL":
-				if nullable || !hasNullableInfo {
-					if val == nil {
-						vals[fieldName] = (*bool)(nil)
-					} else {
-						if *val == "true" || *val == "TRUE" || *val == "1" {
-							vals[fieldName] = &[]bool{true}[0]
-						} else {
-							vals[fieldName] = &[]bool{false}[0]
-						}
-					}
+			} else {
+				if hasNullableInfo {
+
+					return parseUint(*val), nil
+				}
+			}
+		case reflect.Uint8:
+			if nullable || !hasNullableInfo {
+				if val == nil {
+					return (*uint8)(nil), nil
 				} else {
-					if hasNullableInfo {
+					return parseUint8P(*val), nil
+				}
+			} else {
+				if hasNullableInfo {
 
-						if *val == "true" || *val == "TRUE" || *val == "1" {
-							vals[fieldName] = true
-						} else {
-							vals[fieldName] = false
-						}
-					}
+					return parseUint8(*val), nil
 				}
-			case "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
-				if nullable || !hasNullableInfo {
-					if val == nil {
-						vals[fieldName] = (*time.Time)(nil)
-					} else {
-						t, err := time.Parse("2006-01-02 15:04:05", *val)
-						if err != nil {
-							t, _ = time.Parse(time.RFC3339, *val)
-						}
-						vals[fieldName] = &t
-					}
+			}
+		case reflect.Uint16:
+			if nullable || !hasNullableInfo {
+				if val == nil {
+					return (*uint16)(nil), nil
 				} else {
-					if hasNullableInfo {
+					return parseUint16P(*val), nil
+				}
+			} else {
+				if hasNullableInfo {
 
-						t, err := time.Parse("2006-01-02 15:04:05", *val)
-						if err != nil {
-							t, _ = time.Parse(time.RFC3339, *val)
-						}
-						vals[fieldName] = &t
-					}
+					return parseUint16(*val), nil
 				}
-			case "JSON", "JSONB":
+			}
+		case reflect.Uint32:
+			if nullable || !hasNullableInfo {
 				if val == nil {
-					vals[fieldName] = nil
+					return (*uint32)(nil), nil
 				} else {
-					var jData interface{}
-					json.Unmarshal(*raw, &jData)
-					vals[fieldName] = jData
-				}
-			case "DATE":
-				if nullable || !hasNullableInfo {
-					if val == nil {
-						vals[fieldName] = (*civil.Date)(nil)
-					} else {
-						d, err := civil.ParseDate(*val)
-						if err != nil {
-							t, _ := time.Parse(time.RFC3339, *val)
-							d = civil.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
-						}
-						vals[fieldName] = &d
-					}
+					return parseUint32P(*val), nil
+				}
+			} else {
+				if hasNullableInfo {
+
+					return parseUint32(*val), nil
+				}
+			}
+		case reflect.Uint64:
+			if nullable || !hasNullableInfo {
+				if val == nil {
+					return (*uint64)(nil), nil
 				} else {
-					if hasNullableInfo {
+					return parseUint64P(*val), nil
+				}
+			} else {
+				if hasNullableInfo {
 
-						d, err := civil.ParseDate(*val)
-						if err != nil {
-							t, _ := time.Parse(time.RFC3339, *val)
-							d = civil.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
-						}
-						vals[fieldName] = d
-					}
+					return parseUint64(*val), nil
 				}
-			case "TIME":
-				if nullable || !hasNullableInfo {
-					if val == nil {
-						vals[fieldName] = (*civil.Time)(nil)
-					} else {
-						t, _ := civil.ParseTime(*val)
-						vals[fieldName] = &t
-					}
+			}
+		case reflect.Int:
+			if nullable || !hasNullableInfo {
+				if val == nil {
+					return (*int)(nil), nil
+				} else {
+					return parseIntP(*val), nil
+				}
+			} else {
+				if hasNullableInfo {
+
+					return parseInt(*val), nil
+				}
+			}
+		case reflect.Int8:
+			if nullable || !hasNullableInfo {
+				if val == nil {
+					return (*int8)(nil), nil
 				} else {
-					if hasNullableInfo {
+					return parseInt8P(*val), nil
+				}
+			} else {
+				if hasNullableInfo {
 
-						t, _ := civil.ParseTime(*val)
-						vals[fieldName] = t
-					}
+					return parseInt8(*val), nil
+				}
+			}
+		case reflect.Int16:
+			if nullable || !hasNullableInfo {
+				if val == nil {
+					return (*int16)(nil), nil
+				} else {
+					return parseInt16P(*val), nil
 				}
+			} else {
+				if hasNullableInfo {
 
-			default:
+					return parseInt16(*val), nil
+				}
+			}
+		case reflect.Int32:
+			if nullable || !hasNullableInfo {
+				if val == nil {
+					return (*int32)(nil), nil
+				} else {
+					return parseInt32P(*val), nil
+				}
+			} else {
+				if hasNullableInfo {
 
-				if nullable || !hasNullableInfo {
-					vals[fieldName] = val
+					return parseInt32(*val), nil
+				}
+			}
+		case reflect.Int64:
+			if nullable || !hasNullableInfo {
+				if val == nil {
+					return (*int64)(nil), nil
 				} else {
-					if hasNullableInfo {
+					return parseInteger(*val, true), nil
+				}
+			} else {
+				if hasNullableInfo {
 
-						vals[fieldName] = *val
-					}
+					return parseInteger(*val, false), nil
+				}
+			}
+		default:
+			if nullable || !hasNullableInfo {
+				if val == nil {
+					return (*int64)(nil), nil
+				} else {
+					return parseInteger(*val, true), nil
+				}
+			} else {
+				if hasNullableInfo {
+
+					return parseInteger(*val, false), nil
+				}
+			}
+		}
+	case "BOOL":
+		if nullable || !hasNullableInfo {
+			if val == nil {
+				return (*bool)(nil), nil
+			} else {
+				return &[]bool{parseBool(o, *val)}[0], nil
+			}
+		} else {
+			if hasNullableInfo {
+
+				return parseBool(o, *val), nil
+			}
+		}
+	case "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
+		if nullable || !hasNullableInfo {
+			if val == nil {
+				return (*time.Time)(nil), nil
+			} else {
+				t, err := time.Parse("2006-01-02 15:04:05", *val)
+				if err != nil {
+					t, _ = time.Parse(time.RFC3339, *val)
+				}
+				return &t, nil
+			}
+		} else {
+			if hasNullableInfo {
+
+				t, err := time.Parse("2006-01-02 15:04:05", *val)
+				if err != nil {
+					t, _ = time.Parse(time.RFC3339, *val)
+				}
+				return &t, nil
+			}
+		}
+	case "JSON", "JSONB":
+		if val == nil {
+			return nil, nil
+		} else {
+			var jData interface{}
+			json.Unmarshal(raw, &jData)
+			return jData, nil
+		}
+	case "DATE":
+		if nullable || !hasNullableInfo {
+			if val == nil {
+				return (*civil.Date)(nil), nil
+			} else {
+				d, err := civil.ParseDate(*val)
+				if err != nil {
+					t, _ := time.Parse(time.RFC3339, *val)
+					d = civil.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+				}
+				return &d, nil
+			}
+		} else {
+			if hasNullableInfo {
+
+				d, err := civil.ParseDate(*val)
+				if err != nil {
+					t, _ := time.Parse(time.RFC3339, *val)
+					d = civil.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+				}
+				return d, nil
+			}
+		}
+	case "TIME":
+		if nullable || !hasNullableInfo {
+			if val == nil {
+				return (*civil.Time)(nil), nil
+			} else {
+				t, _ := civil.ParseTime(*val)
+				return &t, nil
+			}
+		} else {
+			if hasNullableInfo {
+
+				t, _ := civil.ParseTime(*val)
+				return t, nil
+			}
+		}
+	case "YEAR":
+		if nullable || !hasNullableInfo {
+			if val == nil {
+				if o.YearAsTime {
+					return (*time.Time)(nil), nil
+				}
+				return (*int)(nil), nil
+			} else {
+				if o.YearAsTime {
+					return &[]time.Time{parseYear(*val)}[0], nil
+				}
+				return parseIntP(*val), nil
+			}
+		} else {
+			if hasNullableInfo {
+
+				if o.YearAsTime {
+					return parseYear(*val), nil
 				}
+				return parseInt(*val), nil
+			}
+		}
+
+	default:
+		if o.FallbackHandler != nil {
+			return o.FallbackHandler(fieldName, colType, raw, nullable || !hasNullableInfo)
+		}
+
+		if o.FailOnUnknownType {
+			return nil, &UnknownTypeError{Column: fieldName, DBType: colType}
+		}
+
+		if nullable || !hasNullableInfo {
+			return val, nil
+		} else {
+			if hasNullableInfo {
+
+				return *val, nil
 			}
 		}
-		outMap = append(outMap, vals)
 	}
+	return nil, nil
+}
 
-	err = rows.Close()
+// structDecoder uses the mapstructure package to convert vals into new ConcreteStructs.
+// One is created per query so that the decoder is only configured once rather than for each row.
+type structDecoder struct {
+	typ     reflect.Type
+	target  reflect.Value // reused *ConcreteStruct that is decoded into
+	decoder *mapstructure.Decoder
+}
+
+// newStructDecoder creates a structDecoder for o.ConcreteStruct. The `dbq` struct tags are
+// used regardless of whether DecoderConfig is set.
+func newStructDecoder(o *Options) (*structDecoder, error) {
+	d := &structDecoder{typ: reflect.TypeOf(o.ConcreteStruct)}
+	d.target = reflect.New(d.typ)
+
+	dc := &mapstructure.DecoderConfig{
+		DecodeHook:       decodeHook(nil),
+		ZeroFields:       true,
+		TagName:          "dbq",
+		WeaklyTypedInput: true,
+		Result:           d.target.Interface(),
+	}
+	if o.DecoderConfig != nil {
+		dc.DecodeHook = decodeHook(o.DecoderConfig.DecodeHook)
+		dc.WeaklyTypedInput = o.DecoderConfig.WeaklyTypedInput
+	}
+
+	var err error
+	d.decoder, err = mapstructure.NewDecoder(dc)
 	if err != nil {
 		return nil, err
 	}
+	return d, nil
+}
 
-	if err := rows.Err(); err != nil {
+// decode converts vals into a new ConcreteStruct.
+func (d *structDecoder) decode(vals map[string]interface{}) (interface{}, error) {
+
+	d.target.Elem().Set(reflect.Zero(d.typ))
+
+	if err := d.decoder.Decode(vals); err != nil {
 		return nil, err
 	}
 
+	res := reflect.New(d.typ)
+	res.Elem().Set(d.target.Elem())
+	return res.Interface(), nil
+}
+
+// finishQ calls PostFetch, PostUnmarshal and validates the results fetched by Q.
+func finishQ(ctx context.Context, o *Options, outStruct interface{}, outMap []map[string]interface{}, outRows [][]interface{}, postUnmarshal bool) (out interface{}, rErr error) {
+	if o.OnChecksum != nil && !o.PositionalRows {
+		defer func() {
+			if rErr != nil {
+				return
+			}
+
+			sum, err := Checksums(out, o.ChecksumColumns...)
+			if err != nil {
+				out, rErr = nil, err
+				return
+			}
+			o.OnChecksum(ctx, sum)
+		}()
+	}
+
 	if o.PostFetch != nil {
 		err := o.PostFetch(ctx)
 		if err != nil {
@@ -575,9 +1155,38 @@ func Q(ctx context.Context, db interface{}, query string, options *Options, args
 					}
 				}
 			}
+
+			if err := validate(rows, o.Validator); err != nil {
+				return nil, err
+			}
 		}
 		return outStruct.(reflect.Value).Interface(), nil
 	}
 
+	if o.PositionalRows {
+		if o.Validator != nil {
+			if err := validate(reflect.ValueOf(outRows), o.Validator); err != nil {
+				return nil, err
+			}
+		}
+		return outRows, nil
+	}
+
+	if o.StringResults {
+		strOut := stringResults(outMap, o.NullString)
+		if o.Validator != nil {
+			if err := validate(reflect.ValueOf(strOut), o.Validator); err != nil {
+				return nil, err
+			}
+		}
+		return strOut, nil
+	}
+
+	if o.Validator != nil {
+		if err := validate(reflect.ValueOf(outMap), o.Validator); err != nil {
+			return nil, err
+		}
+	}
+
 	return outMap, nil
 }
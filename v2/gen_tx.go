@@ -7,7 +7,10 @@ package dbq
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"golang.org/x/xerrors"
+	"strings"
 	"time"
 
 	rlSql "github.com/rocketlaunchr/mysql-go"
@@ -29,6 +32,92 @@ type EFn func(ctx context.Context, query string, options *Options, args ...inter
 // TxCommit will commit the transaction.
 type TxCommit func() error
 
+// TxOptions is used to configure a transaction.
+type TxOptions struct {
+
+	// Isolation sets the transaction isolation level. The default is the database's default level.
+	Isolation sql.IsolationLevel
+
+	// ReadOnly can be set to true if the transaction does not modify the database.
+	ReadOnly bool
+
+	// DBType sets the database being used. The default is MySQL.
+	// If the driver does not support Isolation or ReadOnly, a SET TRANSACTION
+	// statement is issued instead for PostgreSQL and SQLServer.
+	DBType Database
+
+	// CockroachRestart can be set to true for CockroachDB's transaction retry protocol to be used.
+	// A "cockroach_restart" savepoint is created at the start of the transaction. When a statement
+	// fails with a retryable error (SQLSTATE 40001), the transaction is rolled back to the savepoint
+	// and fn is called again after waiting according to RetryPolicy (default: exponential for up to 60 seconds).
+	// fn must therefore be safe to call multiple times.
+	CockroachRestart bool
+
+	// RetryPolicy can be set if you want to retry the transaction in the event of failure.
+	RetryPolicy backoff.BackOff
+}
+
+func (o *TxOptions) txOptions() *sql.TxOptions {
+	if o.Isolation == sql.LevelDefault && !o.ReadOnly {
+		return nil
+	}
+	return &sql.TxOptions{Isolation: o.Isolation, ReadOnly: o.ReadOnly}
+}
+
+// setTransactionRequired returns true if err indicates that the driver does
+// not support the requested options, but a SET TRANSACTION statement can be used instead.
+func (o *TxOptions) setTransactionRequired(err error) bool {
+	if o.DBType != PostgreSQL && o.DBType != SQLServer {
+		return false
+	}
+	return strings.Contains(err.Error(), "sql: driver does not support")
+}
+
+// setTransaction configures the transaction tx using SET TRANSACTION statements.
+func (o *TxOptions) setTransaction(ctx context.Context, tx ExecContexter) error {
+	var stmts []string
+
+	if o.Isolation != sql.LevelDefault {
+		var level string
+		switch o.Isolation {
+		case sql.LevelReadUncommitted:
+			level = "READ UNCOMMITTED"
+		case sql.LevelReadCommitted:
+			level = "READ COMMITTED"
+		case sql.LevelRepeatableRead:
+			level = "REPEATABLE READ"
+		case sql.LevelSnapshot:
+			if o.DBType != SQLServer {
+				tx.(txer).Rollback()
+				return fmt.Errorf("isolation level %s is not supported", o.Isolation)
+			}
+			level = "SNAPSHOT"
+		case sql.LevelSerializable:
+			level = "SERIALIZABLE"
+		default:
+			tx.(txer).Rollback()
+			return fmt.Errorf("isolation level %s is not supported", o.Isolation)
+		}
+		stmts = append(stmts, "SET TRANSACTION ISOLATION LEVEL "+level)
+	}
+
+	if o.ReadOnly {
+		if o.DBType == SQLServer {
+			tx.(txer).Rollback()
+			return errors.New("read-only transactions are not supported")
+		}
+		stmts = append(stmts, "SET TRANSACTION READ ONLY")
+	}
+
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			tx.(txer).Rollback()
+			return err
+		}
+	}
+	return nil
+}
+
 // Tx is used to perform an arbitrarily complex operation and not have to worry about rolling back a transaction.
 // The transaction is automatically rolled back unless explicitly committed by calling txCommit.
 // tx is only exposed for performance purposes. Do not use it to commit or rollback.
@@ -50,9 +139,32 @@ type TxCommit func() error
 //  })
 //
 func Tx(ctx context.Context, db interface{}, fn func(tx interface{}, Q QFn, E EFn, txCommit TxCommit), retryPolicy ...backoff.BackOff) error {
+	opts := &TxOptions{}
+	if len(retryPolicy) > 0 {
+		opts.RetryPolicy = retryPolicy[0]
+	}
+	return TxWithOptions(ctx, db, fn, opts)
+}
+
+// ReadOnlyTx operates the same as Tx except that the transaction is read-only.
+// It is suitable for reporting queries that require a consistent view of the database.
+func ReadOnlyTx(ctx context.Context, db interface{}, fn func(tx interface{}, Q QFn, E EFn, txCommit TxCommit), retryPolicy ...backoff.BackOff) error {
+	opts := &TxOptions{ReadOnly: true}
+	if len(retryPolicy) > 0 {
+		opts.RetryPolicy = retryPolicy[0]
+	}
+	return TxWithOptions(ctx, db, fn, opts)
+}
+
+// TxWithOptions operates the same as Tx except that the transaction can be configured with opts.
+// opts has no effect if db is already a transaction.
+func TxWithOptions(ctx context.Context, db interface{}, fn func(tx interface{}, Q QFn, E EFn, txCommit TxCommit), opts *TxOptions) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	if opts == nil {
+		opts = &TxOptions{}
+	}
 	var (
 		alreadyTx bool
 		tx        interface{}
@@ -61,12 +173,24 @@ func Tx(ctx context.Context, db interface{}, fn func(tx interface{}, Q QFn, E EF
 
 	switch db := db.(type) {
 	case BeginTxer:
-		tx, err = db.BeginTx(ctx, nil)
+		tx, err = db.BeginTx(ctx, opts.txOptions())
+		if err != nil && opts.setTransactionRequired(err) {
+			tx, err = db.BeginTx(ctx, nil)
+			if err == nil {
+				err = opts.setTransaction(ctx, tx.(ExecContexter))
+			}
+		}
 		if err != nil {
 			return err
 		}
 	case beginTxer2:
-		tx, err = db.BeginTx(ctx, nil)
+		tx, err = db.BeginTx(ctx, opts.txOptions())
+		if err != nil && opts.setTransactionRequired(err) {
+			tx, err = db.BeginTx(ctx, nil)
+			if err == nil {
+				err = opts.setTransaction(ctx, tx.(ExecContexter))
+			}
+		}
 		if err != nil {
 			return err
 		}
@@ -84,6 +208,10 @@ func Tx(ctx context.Context, db interface{}, fn func(tx interface{}, Q QFn, E EF
 		}
 	}()
 
+	if opts.CockroachRestart && !alreadyTx {
+		return cockroachTx(ctx, tx, fn, opts.RetryPolicy)
+	}
+
 	qFn := func(ctx context.Context, query string, options *Options, args ...interface{}) (interface{}, error) {
 		res, err := Q(ctx, tx, query, options, args...)
 		if err == sql.ErrTxDone && !alreadyTx {
@@ -128,10 +256,140 @@ func Tx(ctx context.Context, db interface{}, fn func(tx interface{}, Q QFn, E EF
 		return nil
 	}
 
-	if !(len(retryPolicy) > 0 && retryPolicy[0] != nil) {
+	if opts.RetryPolicy == nil {
 
 		return operation()
 	}
 
-	return backoff.Retry(operation, backoff.WithContext(retryPolicy[0], ctx))
+	return backoff.Retry(operation, backoff.WithContext(opts.RetryPolicy, ctx))
+}
+
+const cockroachSavepoint = "cockroach_restart"
+
+// cockroachTx implements CockroachDB's client-side transaction retry protocol.
+//
+// See: https://www.cockroachlabs.com/docs/stable/advanced-client-side-transaction-retries.html
+func cockroachTx(ctx context.Context, tx interface{}, fn func(tx interface{}, Q QFn, E EFn, txCommit TxCommit), retryPolicy backoff.BackOff) error {
+	if err := Savepoint(ctx, tx, cockroachSavepoint); err != nil {
+		tx.(txer).Rollback()
+		return err
+	}
+
+	var (
+		completed bool
+		retryErr  error
+	)
+
+	defer func() {
+		if !completed {
+			tx.(txer).Rollback()
+		}
+	}()
+
+	qFn := func(ctx context.Context, query string, options *Options, args ...interface{}) (interface{}, error) {
+		res, err := Q(ctx, tx, query, options, args...)
+		if isRetryable(err) {
+			retryErr = err
+		}
+		return res, err
+	}
+
+	eFn := func(ctx context.Context, query string, options *Options, args ...interface{}) (sql.Result, error) {
+		res, err := E(ctx, tx.(ExecContexter), query, options, args...)
+		if isRetryable(err) {
+			retryErr = err
+		}
+		return res, err
+	}
+
+	txCommit := func() error {
+		err := Release(ctx, tx, cockroachSavepoint)
+		if err != nil {
+			if isRetryable(err) {
+				retryErr = err
+			}
+			return err
+		}
+
+		err = tx.(txer).Commit()
+		if err == nil || err == sql.ErrTxDone {
+			completed = true
+			return nil
+		}
+		return err
+	}
+
+	operation := func() error {
+		retryErr = nil
+		fn(tx, qFn, eFn, txCommit)
+		if completed || retryErr == nil {
+			return nil
+		}
+
+		err := RollbackTo(ctx, tx, cockroachSavepoint)
+		if err != nil {
+			return &backoff.PermanentError{err}
+		}
+		return retryErr
+	}
+
+	if retryPolicy == nil {
+		retryPolicy = ExponentialRetryPolicy(60 * time.Second)
+	}
+
+	return backoff.Retry(operation, backoff.WithContext(retryPolicy, ctx))
+}
+
+// isRetryable returns true if err is a serialization failure (SQLSTATE 40001).
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var sqlState interface{ SQLState() string }
+	if xerrors.As(err, &sqlState) {
+		return sqlState.SQLState() == "40001"
+	}
+	return strings.Contains(err.Error(), "40001")
+}
+
+// txWrapper is implemented by wrappers (such as ReplicaSet and StmtCache) that implement BeginTx
+// but can only begin a transaction if the pool they wrap can.
+type txWrapper interface {
+	beginsTx() bool
+}
+
+// canBeginTx returns true if db can begin a transaction.
+func canBeginTx(db interface{}) bool {
+	if w, ok := db.(txWrapper); ok {
+		return w.beginsTx()
+	}
+
+	switch db.(type) {
+	case BeginTxer, beginTxer2:
+		return true
+	}
+	return false
+}
+
+// inTx calls fn within a new transaction. The transaction is committed if fn succeeds.
+// Otherwise it is rolled back.
+func inTx(ctx context.Context, db interface{}, fn func(tx interface{}) (interface{}, error)) (interface{}, error) {
+	var (
+		out interface{}
+		err error
+	)
+
+	txErr := Tx(ctx, db, func(tx interface{}, Q QFn, E EFn, txCommit TxCommit) {
+		out, err = fn(tx)
+		if err != nil {
+			return
+		}
+		err = txCommit()
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return out, err
 }
@@ -26,6 +26,7 @@ require (
 	github.com/sirupsen/logrus v1.4.2 // indirect
 	github.com/stretchr/testify v1.4.0 // indirect
 	golang.org/x/sync v0.0.0-20190423024810-112230192c58
+	golang.org/x/text v0.3.2
 	golang.org/x/xerrors v0.0.0-20191011141410-1b5146add898
 	gotest.tools v2.2.0+incompatible // indirect
 )
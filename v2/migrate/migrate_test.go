@@ -0,0 +1,85 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package migrate
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	root, err := ioutil.TempDir("", "migrate")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(root)
+
+	files := map[string]string{
+		"0002_add_age.up.sql":        "ALTER TABLE users ADD age INT",
+		"0002_add_age.down.sql":      "ALTER TABLE users DROP age",
+		"0001_create_users.up.sql":   "CREATE TABLE users (id INT)",
+		"0001_create_users.down.sql": "DROP TABLE users",
+		"README.md":                  "ignored",
+	}
+
+	if err := os.Mkdir(filepath.Join(root, "migrations"), 0700); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, contents := range files {
+		if err := ioutil.WriteFile(filepath.Join(root, "migrations", name), []byte(contents), 0600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	migrations, err := Load(http.Dir(root), "migrations")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Migration{
+		{Version: 1, Name: "create_users", Up: "CREATE TABLE users (id INT)", Down: "DROP TABLE users"},
+		{Version: 2, Name: "add_age", Up: "ALTER TABLE users ADD age INT", Down: "ALTER TABLE users DROP age"},
+	}
+
+	if !cmp.Equal(migrations, expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, migrations)
+	}
+}
+
+func TestUp(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	m := NewWithMigrations(db, []Migration{
+		{Version: 1, Name: "create_users", Up: "CREATE TABLE users (id INT)"},
+		{Version: 2, Name: "add_age", Up: "ALTER TABLE users ADD age INT"},
+	}, Options{})
+
+	mock.ExpectExec(`^SELECT GET_LOCK\(\?, -1\)$`).WithArgs("dbq_migrate").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("^CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("^SELECT version FROM schema_migrations").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(int64(1)))
+	mock.ExpectBegin()
+	mock.ExpectExec("^ALTER TABLE users ADD age INT$").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("^INSERT INTO schema_migrations").WithArgs(int64(2), "add_age", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(`^SELECT RELEASE_LOCK\(\?\)$`).WithArgs("dbq_migrate").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = m.Up(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}